@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Budget caps how much a Category may be spent on in a given Month.
+type Budget struct {
+	Month    int
+	Category string
+	Amount   float64
+}
+
+// validBudgets filters out budgets with a Month outside 1-12, guarding
+// against hand-edited or externally-written storage that bypassed
+// SetBudget's validation.
+func validBudgets(budgets []Budget) []Budget {
+	valid := budgets[:0:0]
+	for _, b := range budgets {
+		if b.Month >= 1 && b.Month <= 12 {
+			valid = append(valid, b)
+		}
+	}
+	return valid
+}
+
+// Categories returns the distinct, sorted category names seen across
+// expenses and budgets.
+func (et *ExpenseTracker) Categories() []string {
+	seen := map[string]bool{}
+	for _, e := range et.expenses {
+		if e.Category != "" {
+			seen[e.Category] = true
+		}
+	}
+	for _, b := range et.budgets {
+		seen[b.Category] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RenameCategory renames every expense and budget in category old to
+// newName.
+func (et *ExpenseTracker) RenameCategory(old, newName string) error {
+	for i, e := range et.expenses {
+		if e.Category == old {
+			et.expenses[i].Category = newName
+		}
+	}
+	for i, b := range et.budgets {
+		if b.Category == old {
+			et.budgets[i].Category = newName
+		}
+	}
+	if err := et.repo.Save(et.expenses); err != nil {
+		return err
+	}
+	return et.repo.SaveBudgets(et.budgets)
+}
+
+// DeleteCategory clears category from every expense that has it and removes
+// any budgets set for it.
+func (et *ExpenseTracker) DeleteCategory(category string) error {
+	for i, e := range et.expenses {
+		if e.Category == category {
+			et.expenses[i].Category = ""
+		}
+	}
+	kept := et.budgets[:0:0]
+	for _, b := range et.budgets {
+		if b.Category != category {
+			kept = append(kept, b)
+		}
+	}
+	et.budgets = kept
+	if err := et.repo.Save(et.expenses); err != nil {
+		return err
+	}
+	return et.repo.SaveBudgets(et.budgets)
+}
+
+// SetBudget sets (or replaces) the budget for category in month.
+func (et *ExpenseTracker) SetBudget(month int, category string, amount float64) error {
+	for i, b := range et.budgets {
+		if b.Month == month && b.Category == category {
+			et.budgets[i].Amount = amount
+			return et.repo.SaveBudgets(et.budgets)
+		}
+	}
+	et.budgets = append(et.budgets, Budget{Month: month, Category: category, Amount: amount})
+	return et.repo.SaveBudgets(et.budgets)
+}
+
+// Budgets returns all configured budgets.
+func (et *ExpenseTracker) Budgets() []Budget {
+	return et.budgets
+}
+
+func (et *ExpenseTracker) budgetFor(month int, category string) (Budget, bool) {
+	for _, b := range et.budgets {
+		if b.Month == month && b.Category == category {
+			return b, true
+		}
+	}
+	return Budget{}, false
+}
+
+// categoryMonthTotal sums expenses in category for the given calendar month
+// of the current year, i.e. "month-to-date" for that category.
+func (et *ExpenseTracker) categoryMonthTotal(month int, category string) float64 {
+	year := time.Now().Year()
+	total := 0.0
+	for _, e := range et.expenses {
+		if e.Category == category && int(e.Date.Month()) == month && e.Date.Year() == year {
+			total += e.Amount
+		}
+	}
+	return total
+}
+
+// BudgetWarning reports whether category's month-to-date total is over its
+// budget for month, returning the warning message to print if so. The add
+// command calls it after each add, so it warns on every add while the
+// category stays over budget, not only on the add that crossed it.
+func (et *ExpenseTracker) BudgetWarning(month int, category string) (string, bool) {
+	if category == "" {
+		return "", false
+	}
+	budget, ok := et.budgetFor(month, category)
+	if !ok {
+		return "", false
+	}
+	spent := et.categoryMonthTotal(month, category)
+	if spent <= budget.Amount {
+		return "", false
+	}
+	return fmt.Sprintf("Warning: %s spending for %s is $%.2f, over the $%.2f budget",
+		category, time.Month(month), spent, budget.Amount), true
+}
+
+// printCategoryBreakdown renders a per-category total for Summary, with a
+// "pb"-style ASCII progress bar against the category's budget when one is
+// configured for month (budgets are per-month, so breakdowns for the
+// all-time summary show totals only).
+func (et *ExpenseTracker) printCategoryBreakdown(month int, byCategory map[string]float64) {
+	if len(byCategory) == 0 {
+		return
+	}
+	categories := make([]string, 0, len(byCategory))
+	for c := range byCategory {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
+
+	fmt.Println("By category:")
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, category := range categories {
+		label := category
+		if label == "" {
+			label = "(uncategorized)"
+		}
+		spent := byCategory[category]
+		if month != 0 {
+			if budget, ok := et.budgetFor(month, category); ok && budget.Amount > 0 {
+				percent := spent / budget.Amount * 100
+				fmt.Fprintf(tw, "  %s\t$%.2f\t%s %.0f%% of $%.2f\n",
+					label, spent, progressBar(percent, 20), percent, budget.Amount)
+				continue
+			}
+		}
+		fmt.Fprintf(tw, "  %s\t$%.2f\n", label, spent)
+	}
+	_ = tw.Flush()
+}
+
+// progressBar renders a "[████████░░░░]"-style bar, clamping percent to
+// [0, 100] filled cells across width total cells.
+func progressBar(percent float64, width int) string {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	filled := int(percent / 100 * float64(width))
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
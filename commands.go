@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Context carries the state available to a Command's Action: the tracker it
+// operates on and the args that followed the command name.
+type Context struct {
+	tracker *ExpenseTracker
+	args    []string
+}
+
+// Flag documents one flag a Command accepts. It only drives help text and
+// shell completion; Actions parse their own flag.FlagSet from ctx.args so
+// they keep full control over validation.
+type Flag struct {
+	Name    string
+	Default string
+	Usage   string
+}
+
+// Completion describes a flag's tab-completion behavior for the interactive
+// shell: the flag name and, optionally, a fixed set of values it accepts.
+type Completion struct {
+	Flag   string
+	Values []string
+}
+
+// Command is one entry in the registry, mirroring the dispatch style used by
+// editors like micro: a name, its usage/flags for help and completion, and
+// the Action that runs it.
+type Command struct {
+	Name        string
+	Usage       string
+	Flags       []Flag
+	Action      func(ctx *Context) error
+	Completions []Completion
+}
+
+// commands is the global command registry, populated by each command's
+// init().
+var commands = map[string]Command{}
+
+// register adds cmd to the registry. Call from an init() alongside each
+// command's definition.
+func register(cmd Command) {
+	commands[cmd.Name] = cmd
+}
+
+func sortedCommandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runCommand looks up args[0] in the registry and runs its Action.
+func runCommand(tracker *ExpenseTracker, args []string) {
+	if len(args) == 0 {
+		printUsage()
+		return
+	}
+
+	name := args[0]
+	if name == "help" {
+		if len(args) > 1 {
+			printCommandHelp(args[1])
+		} else {
+			printUsage()
+		}
+		return
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Println("Unknown command:", name)
+		printUsage()
+		return
+	}
+
+	ctx := &Context{tracker: tracker, args: args[1:]}
+	if err := cmd.Action(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+}
+
+// printUsage auto-generates top-level help from the command registry.
+func printUsage() {
+	fmt.Println("Usage: expense-tracker <command> [--flags]")
+	fmt.Println("Commands:")
+	for _, name := range sortedCommandNames() {
+		fmt.Printf("  %s\n", commands[name].Usage)
+	}
+	fmt.Println("Tip: run without args to enter interactive mode. Run 'help <command>' for details.")
+}
+
+// printCommandHelp prints the Usage line and per-flag documentation for a
+// single registered command ("help add").
+func printCommandHelp(name string) {
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Println("Unknown command:", name)
+		return
+	}
+	fmt.Println("Usage:", cmd.Usage)
+	for _, f := range cmd.Flags {
+		fmt.Printf("  --%s\t%s (default %q)\n", f.Name, f.Usage, f.Default)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+func init() {
+	register(Command{
+		Name:   "categories",
+		Usage:  "categories list | categories rename <old> <new> | categories delete <name>",
+		Action: actionCategories,
+	})
+	register(Command{
+		Name:  "budget",
+		Usage: "budget set --month 1..12 --category <name> --amount <number> | budget list",
+		Flags: []Flag{
+			{Name: "month", Default: "0", Usage: "Month number (1-12)"},
+			{Name: "category", Usage: "Category name"},
+			{Name: "amount", Default: "0", Usage: "Budget amount"},
+		},
+		Completions: []Completion{
+			{Flag: "--month", Values: monthNames},
+		},
+		Action: actionBudget,
+	})
+}
+
+func actionCategories(ctx *Context) error {
+	if len(ctx.args) == 0 {
+		fmt.Println("Usage: categories list | categories rename <old> <new> | categories delete <name>")
+		return nil
+	}
+
+	switch ctx.args[0] {
+	case "list":
+		categories := ctx.tracker.Categories()
+		if len(categories) == 0 {
+			fmt.Println("No categories yet")
+			return nil
+		}
+		for _, c := range categories {
+			fmt.Println(c)
+		}
+	case "rename":
+		if len(ctx.args) != 3 {
+			fmt.Println("Usage: categories rename <old> <new>")
+			return nil
+		}
+		if err := ctx.tracker.RenameCategory(ctx.args[1], ctx.args[2]); err != nil {
+			return fmt.Errorf("renaming category: %w", err)
+		}
+		fmt.Println("Category renamed successfully")
+	case "delete":
+		if len(ctx.args) != 2 {
+			fmt.Println("Usage: categories delete <name>")
+			return nil
+		}
+		if err := ctx.tracker.DeleteCategory(ctx.args[1]); err != nil {
+			return fmt.Errorf("deleting category: %w", err)
+		}
+		fmt.Println("Category deleted successfully")
+	default:
+		fmt.Println("Usage: categories list | categories rename <old> <new> | categories delete <name>")
+	}
+	return nil
+}
+
+func actionBudget(ctx *Context) error {
+	if len(ctx.args) == 0 {
+		fmt.Println("Usage: budget set --month 1..12 --category <name> --amount <number> | budget list")
+		return nil
+	}
+
+	switch ctx.args[0] {
+	case "list":
+		budgets := ctx.tracker.Budgets()
+		if len(budgets) == 0 {
+			fmt.Println("No budgets set")
+			return nil
+		}
+		for _, b := range budgets {
+			fmt.Printf("%s: %s $%.2f\n", monthNames[b.Month-1], b.Category, b.Amount)
+		}
+	case "set":
+		cmd := flag.NewFlagSet("budget set", flag.ContinueOnError)
+		month := cmd.Int("month", 0, "Month number (1-12)")
+		category := cmd.String("category", "", "Category name")
+		amount := cmd.Float64("amount", 0, "Budget amount")
+		if err := cmd.Parse(ctx.args[1:]); err != nil {
+			return nil
+		}
+		if *month < 1 || *month > 12 || *category == "" || *amount <= 0 {
+			fmt.Println("Usage: budget set --month 1..12 --category <name> --amount <number>")
+			return nil
+		}
+		if err := ctx.tracker.SetBudget(*month, *category, *amount); err != nil {
+			return fmt.Errorf("setting budget: %w", err)
+		}
+		fmt.Println("Budget set successfully")
+	default:
+		fmt.Println("Usage: budget set --month 1..12 --category <name> --amount <number> | budget list")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+)
+
+func init() {
+	register(Command{
+		Name:  "add",
+		Usage: "add --description <text> --amount <number> [--category <name>]",
+		Flags: []Flag{
+			{Name: "description", Usage: "Expense description"},
+			{Name: "amount", Default: "0", Usage: "Expense amount"},
+			{Name: "category", Usage: "Expense category"},
+		},
+		Action: actionAdd,
+	})
+	register(Command{
+		Name:   "list",
+		Usage:  "list",
+		Action: actionList,
+	})
+	register(Command{
+		Name:  "summary",
+		Usage: "summary [--month 1..12]",
+		Flags: []Flag{
+			{Name: "month", Default: "0", Usage: "Month number (1-12)"},
+		},
+		Completions: []Completion{
+			{Flag: "--month", Values: monthNames},
+		},
+		Action: actionSummary,
+	})
+	register(Command{
+		Name:  "delete",
+		Usage: "delete --id <number>",
+		Flags: []Flag{
+			{Name: "id", Default: "0", Usage: "Expense ID"},
+		},
+		Action: actionDelete,
+	})
+}
+
+func actionAdd(ctx *Context) error {
+	cmd := flag.NewFlagSet("add", flag.ContinueOnError)
+	desc := cmd.String("description", "", "Expense description")
+	amount := cmd.Float64("amount", 0, "Expense amount")
+	category := cmd.String("category", "", "Expense category")
+	if err := cmd.Parse(ctx.args); err != nil {
+		return nil
+	}
+	if *desc == "" || *amount <= 0 {
+		fmt.Println("Usage: add --description <text> --amount <number> [--category <name>]")
+		return nil
+	}
+	id, err := ctx.tracker.Add(*desc, *category, *amount)
+	if err != nil {
+		return fmt.Errorf("adding expense: %w", err)
+	}
+	fmt.Printf("Expense added successfully (ID: %d)\n", id)
+	if warning, over := ctx.tracker.BudgetWarning(int(time.Now().Month()), *category); over {
+		fmt.Println(warning)
+	}
+	return nil
+}
+
+func actionList(ctx *Context) error {
+	cmd := flag.NewFlagSet("list", flag.ContinueOnError)
+	if err := cmd.Parse(ctx.args); err != nil {
+		return nil
+	}
+	ctx.tracker.List()
+	return nil
+}
+
+func actionSummary(ctx *Context) error {
+	cmd := flag.NewFlagSet("summary", flag.ContinueOnError)
+	month := cmd.Int("month", 0, "Month number (1-12)")
+	if err := cmd.Parse(ctx.args); err != nil {
+		return nil
+	}
+	if *month < 0 || *month > 12 {
+		fmt.Println("Usage: summary [--month 1..12]")
+		return nil
+	}
+	ctx.tracker.Summary(*month)
+	return nil
+}
+
+func actionDelete(ctx *Context) error {
+	cmd := flag.NewFlagSet("delete", flag.ContinueOnError)
+	id := cmd.Int("id", 0, "Expense ID")
+	if err := cmd.Parse(ctx.args); err != nil {
+		return nil
+	}
+	if *id <= 0 {
+		fmt.Println("Usage: delete --id <number>")
+		return nil
+	}
+	deleted, err := ctx.tracker.Delete(*id)
+	if err != nil {
+		return fmt.Errorf("deleting expense: %w", err)
+	}
+	if deleted {
+		fmt.Println("Expense deleted successfully")
+	} else {
+		fmt.Println("Expense not found")
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+func init() {
+	register(Command{
+		Name:  "export",
+		Usage: "export --format csv|json [--month 1..12] [--category <name>] [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--out path]",
+		Flags: []Flag{
+			{Name: "format", Usage: "Output format: csv or json"},
+			{Name: "month", Default: "0", Usage: "Filter to month number (1-12)"},
+			{Name: "category", Usage: "Filter to category"},
+			{Name: "from", Usage: "Filter to expenses on/after this date (YYYY-MM-DD)"},
+			{Name: "to", Usage: "Filter to expenses on/before this date (YYYY-MM-DD)"},
+			{Name: "out", Usage: "Output file path (default: stdout)"},
+		},
+		Completions: []Completion{
+			{Flag: "--format", Values: []string{"csv", "json"}},
+			{Flag: "--month", Values: monthNames},
+		},
+		Action: actionExport,
+	})
+	register(Command{
+		Name:  "import",
+		Usage: "import --format csv|json --in path [--replace]",
+		Flags: []Flag{
+			{Name: "format", Usage: "Input format: csv or json"},
+			{Name: "in", Usage: "Input file path"},
+			{Name: "replace", Default: "false", Usage: "Replace all existing expenses with the imported set (default: merge, reassigning conflicting IDs)"},
+		},
+		Completions: []Completion{
+			{Flag: "--format", Values: []string{"csv", "json"}},
+		},
+		Action: actionImport,
+	})
+}
+
+func actionExport(ctx *Context) error {
+	cmd := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := cmd.String("format", "", "Output format: csv or json")
+	month := cmd.Int("month", 0, "Filter to month number (1-12)")
+	category := cmd.String("category", "", "Filter to category")
+	from := cmd.String("from", "", "Filter to expenses on/after this date (YYYY-MM-DD)")
+	to := cmd.String("to", "", "Filter to expenses on/before this date (YYYY-MM-DD)")
+	out := cmd.String("out", "", "Output file path (default: stdout)")
+	if err := cmd.Parse(ctx.args); err != nil {
+		return nil
+	}
+	if *format != "csv" && *format != "json" {
+		fmt.Println("Usage: export --format csv|json [--month 1..12] [--category <name>] [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--out path]")
+		return nil
+	}
+
+	filter := ExpenseFilter{Month: *month, Category: *category}
+	if *from != "" {
+		date, err := time.Parse("2006-01-02", *from)
+		if err != nil {
+			return fmt.Errorf("invalid --from date: %w", err)
+		}
+		filter.From = date
+	}
+	if *to != "" {
+		date, err := time.Parse("2006-01-02", *to)
+		if err != nil {
+			return fmt.Errorf("invalid --to date: %w", err)
+		}
+		filter.To = date
+	}
+
+	expenses, err := ctx.tracker.Query(filter)
+	if err != nil {
+		return fmt.Errorf("querying expenses: %w", err)
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch *format {
+	case "csv":
+		err = writeExpensesCSV(w, expenses)
+	case "json":
+		err = writeExpensesJSON(w, expenses)
+	}
+	if err != nil {
+		return fmt.Errorf("exporting expenses: %w", err)
+	}
+	if *out != "" {
+		fmt.Printf("Exported %d expense(s) to %s\n", len(expenses), *out)
+	}
+	return nil
+}
+
+func actionImport(ctx *Context) error {
+	cmd := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := cmd.String("format", "", "Input format: csv or json")
+	in := cmd.String("in", "", "Input file path")
+	replace := cmd.Bool("replace", false, "Replace all existing expenses with the imported set (default: merge, reassigning conflicting IDs)")
+	if err := cmd.Parse(ctx.args); err != nil {
+		return nil
+	}
+	if (*format != "csv" && *format != "json") || *in == "" {
+		fmt.Println("Usage: import --format csv|json --in path [--replace]")
+		return nil
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		return fmt.Errorf("opening input file: %w", err)
+	}
+	defer f.Close()
+
+	var imported []Expense
+	var rejected int
+	switch *format {
+	case "csv":
+		imported, rejected, err = readExpensesCSV(f)
+	case "json":
+		imported, rejected, err = readExpensesJSON(f)
+	}
+	if err != nil {
+		return fmt.Errorf("reading import file: %w", err)
+	}
+
+	existing := len(ctx.tracker.expenses)
+	accepted, err := ctx.tracker.Import(imported, *replace)
+	if err != nil {
+		if errors.Is(err, ErrEmptyReplace) {
+			fmt.Println("Error: import file has no valid records; refusing to replace existing expenses")
+			return nil
+		}
+		return fmt.Errorf("importing expenses: %w", err)
+	}
+	if *replace {
+		fmt.Printf("Replaced %d existing record(s) with %d imported, rejected %d\n", existing, accepted, rejected)
+	} else {
+		fmt.Printf("Imported %d record(s), rejected %d\n", accepted, rejected)
+	}
+	return nil
+}
+
+func writeExpensesCSV(w io.Writer, expenses []Expense) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, e := range expenses {
+		if err := cw.Write(expenseToCSVRow(e)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeExpensesJSON(w io.Writer, expenses []Expense) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(expenses)
+}
+
+func readExpensesCSV(r io.Reader) ([]Expense, int, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(rows) == 0 {
+		return nil, 0, nil
+	}
+
+	var expenses []Expense
+	rejected := 0
+	for _, row := range rows[1:] { // skip header
+		e, err := expenseFromCSVRow(row)
+		if err != nil || !validExpense(e) {
+			rejected++
+			continue
+		}
+		expenses = append(expenses, e)
+	}
+	return expenses, rejected, nil
+}
+
+func readExpensesJSON(r io.Reader) ([]Expense, int, error) {
+	var expenses []Expense
+	if err := json.NewDecoder(r).Decode(&expenses); err != nil {
+		return nil, 0, err
+	}
+
+	var accepted []Expense
+	rejected := 0
+	for _, e := range expenses {
+		if !validExpense(e) {
+			rejected++
+			continue
+		}
+		accepted = append(accepted, e)
+	}
+	return accepted, rejected, nil
+}
+
+func validExpense(e Expense) bool {
+	return e.Description != "" && e.Amount > 0 && !e.Date.IsZero()
+}
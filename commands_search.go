@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+func init() {
+	register(Command{
+		Name:   "search",
+		Usage:  "search <query> (requires --storage sqlite, built with -tags fts5)",
+		Action: actionSearch,
+	})
+}
+
+func actionSearch(ctx *Context) error {
+	cmd := flag.NewFlagSet("search", flag.ContinueOnError)
+	if err := cmd.Parse(ctx.args); err != nil {
+		return nil
+	}
+	query := strings.Join(cmd.Args(), " ")
+	if query == "" {
+		fmt.Println("Usage: search <query>")
+		return nil
+	}
+
+	expenses, err := ctx.tracker.Search(query)
+	if err != nil {
+		return fmt.Errorf("searching descriptions: %w", err)
+	}
+	if len(expenses) == 0 {
+		fmt.Println("No matching expenses")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tDate\tDescription\tCategory\tAmount")
+	for _, e := range expenses {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t$%.2f\n",
+			e.ID, e.Date.Format("2006-01-02"), e.Description, e.Category, e.Amount)
+	}
+	_ = tw.Flush()
+	return nil
+}
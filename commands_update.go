@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+)
+
+func init() {
+	register(Command{
+		Name:  "update",
+		Usage: "update --id N [--description <text>] [--amount <number>] [--category <name>] [--date YYYY-MM-DD]",
+		Flags: []Flag{
+			{Name: "id", Default: "0", Usage: "Expense ID"},
+			{Name: "description", Usage: "New description"},
+			{Name: "amount", Usage: "New amount"},
+			{Name: "category", Usage: "New category"},
+			{Name: "date", Usage: "New date (YYYY-MM-DD)"},
+		},
+		Action: actionUpdate,
+	})
+}
+
+func actionUpdate(ctx *Context) error {
+	cmd := flag.NewFlagSet("update", flag.ContinueOnError)
+	id := cmd.Int("id", 0, "Expense ID")
+	description := cmd.String("description", "", "New description")
+	amount := cmd.Float64("amount", 0, "New amount")
+	category := cmd.String("category", "", "New category")
+	date := cmd.String("date", "", "New date (YYYY-MM-DD)")
+	if err := cmd.Parse(ctx.args); err != nil {
+		return nil
+	}
+	if *id <= 0 {
+		fmt.Println("Usage: update --id <number> [--description <text>] [--amount <number>] [--category <name>] [--date YYYY-MM-DD]")
+		return nil
+	}
+
+	var patch ExpensePatch
+	var dateErr error
+	cmd.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "description":
+			patch.Description = description
+		case "amount":
+			patch.Amount = amount
+		case "category":
+			patch.Category = category
+		case "date":
+			parsed, err := time.Parse("2006-01-02", *date)
+			if err != nil {
+				dateErr = err
+				return
+			}
+			patch.Date = &parsed
+		}
+	})
+	if dateErr != nil {
+		return fmt.Errorf("%w: --date must be YYYY-MM-DD", ErrInvalidField)
+	}
+
+	err := ctx.tracker.Update(*id, patch)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		fmt.Println("Expense not found")
+		return nil
+	case err != nil:
+		return fmt.Errorf("updating expense: %w", err)
+	}
+	fmt.Println("Expense updated successfully")
+	return nil
+}
@@ -0,0 +1,16 @@
+package main
+
+import "errors"
+
+// ErrNotFound is returned by Repository and ExpenseTracker operations that
+// target an expense ID that does not exist.
+var ErrNotFound = errors.New("expense not found")
+
+// ErrInvalidField is returned (wrapped) by ExpenseTracker.Update when the
+// patch's amount is non-positive, and by the update command when --date is
+// not a valid YYYY-MM-DD date.
+var ErrInvalidField = errors.New("invalid field")
+
+// ErrEmptyReplace is returned by ExpenseTracker.Import when a replace-mode
+// import would discard existing expenses in favor of an empty import set.
+var ErrEmptyReplace = errors.New("refusing to replace existing expenses with an empty import set")
@@ -1,8 +1,6 @@
 package main
 
 import (
-	"bufio"
-	"flag"
 	"fmt"
 	"os"
 	"strings"
@@ -14,28 +12,58 @@ type Expense struct {
 	ID          int
 	Date        time.Time
 	Description string
+	Category    string
 	Amount      float64
 }
 
+// ExpenseTracker is the in-memory view over whatever Repository backs it.
+// All mutating methods write through to the repository, so expenses persist
+// across runs. Add, Delete and Import write to the repository before updating
+// the cached slices; Update and the category and budget methods update the
+// cached slices first and then save them.
 type ExpenseTracker struct {
 	expenses []Expense
+	budgets  []Budget
 	nextID   int
+	repo     Repository
 }
 
-func NewExpenseTracker() *ExpenseTracker {
-	return &ExpenseTracker{nextID: 1}
+// NewExpenseTracker loads the current contents of repo and returns a tracker
+// backed by it.
+func NewExpenseTracker(repo Repository) (*ExpenseTracker, error) {
+	et := &ExpenseTracker{nextID: 1, repo: repo}
+	expenses, err := repo.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading expenses: %w", err)
+	}
+	et.expenses = expenses
+	for _, e := range expenses {
+		if e.ID >= et.nextID {
+			et.nextID = e.ID + 1
+		}
+	}
+	budgets, err := repo.LoadBudgets()
+	if err != nil {
+		return nil, fmt.Errorf("loading budgets: %w", err)
+	}
+	et.budgets = validBudgets(budgets)
+	return et, nil
 }
 
-func (et *ExpenseTracker) Add(description string, amount float64) int {
+func (et *ExpenseTracker) Add(description, category string, amount float64) (int, error) {
 	expense := Expense{
 		ID:          et.nextID,
 		Date:        time.Now(),
 		Description: description,
+		Category:    category,
 		Amount:      amount,
 	}
+	if err := et.repo.Add(expense); err != nil {
+		return 0, err
+	}
 	et.expenses = append(et.expenses, expense)
 	et.nextID++
-	return expense.ID
+	return expense.ID, nil
 }
 
 func (et *ExpenseTracker) List() {
@@ -44,19 +72,21 @@ func (et *ExpenseTracker) List() {
 		return
 	}
 	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(tw, "ID\tDate\tDescription\tAmount")
+	fmt.Fprintln(tw, "ID\tDate\tDescription\tCategory\tAmount")
 	for _, e := range et.expenses {
-		fmt.Fprintf(tw, "%d\t%s\t%s\t$%.2f\n",
-			e.ID, e.Date.Format("2006-01-02"), e.Description, e.Amount)
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t$%.2f\n",
+			e.ID, e.Date.Format("2006-01-02"), e.Description, e.Category, e.Amount)
 	}
 	_ = tw.Flush()
 }
 
 func (et *ExpenseTracker) Summary(month int) {
 	total := 0.0
+	byCategory := map[string]float64{}
 	for _, e := range et.expenses {
 		if month == 0 || int(e.Date.Month()) == month {
 			total += e.Amount
+			byCategory[e.Category] += e.Amount
 		}
 	}
 	if month == 0 {
@@ -64,123 +94,151 @@ func (et *ExpenseTracker) Summary(month int) {
 	} else {
 		fmt.Printf("Total expenses for %s: $%.2f\n", time.Month(month), total)
 	}
+	et.printCategoryBreakdown(month, byCategory)
 }
 
-func (et *ExpenseTracker) Delete(id int) bool {
+func (et *ExpenseTracker) Delete(id int) (bool, error) {
+	if err := et.repo.Delete(id); err != nil {
+		if err == ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
 	for i, e := range et.expenses {
 		if e.ID == id {
 			et.expenses = append(et.expenses[:i], et.expenses[i+1:]...)
-			return true
+			break
 		}
 	}
-	return false
+	return true, nil
 }
 
-func main() {
-	tracker := NewExpenseTracker()
+// Query returns the expenses matching filter, delegating to the repository
+// so callers (e.g. export) don't re-filter the in-memory cache themselves.
+func (et *ExpenseTracker) Query(filter ExpenseFilter) ([]Expense, error) {
+	return et.repo.Query(filter)
+}
 
-	// Без аргументов — запустим интерактивный режим (удобно для in-memory)
-	if len(os.Args) < 2 {
-		fmt.Println("Expense Tracker (in-memory). Type 'help' for commands, 'exit' to quit.")
-		repl(tracker)
-		return
+// Search performs a full-text search over expense descriptions. It requires
+// a repository that implements Searcher (currently --storage sqlite, built
+// with -tags fts5).
+func (et *ExpenseTracker) Search(query string) ([]Expense, error) {
+	searcher, ok := et.repo.(Searcher)
+	if !ok {
+		return nil, fmt.Errorf("full-text search requires --storage sqlite (built with -tags fts5)")
 	}
-	runCommand(tracker, os.Args[1:])
+	return searcher.SearchDescriptions(query)
 }
 
-func runCommand(tracker *ExpenseTracker, args []string) {
-	if len(args) == 0 {
-		printUsage()
-		return
+// ExpensePatch describes a partial update to an Expense: nil fields are left
+// untouched, so only flags the caller actually passed end up applied.
+type ExpensePatch struct {
+	Description *string
+	Category    *string
+	Amount      *float64
+	Date        *time.Time
+}
+
+// Update applies patch to the expense with the given id. It returns
+// ErrNotFound if no such expense exists, or ErrInvalidField if patch.Amount
+// is non-positive.
+func (et *ExpenseTracker) Update(id int, patch ExpensePatch) error {
+	if patch.Amount != nil && *patch.Amount <= 0 {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidField)
 	}
 
-	switch args[0] {
-	case "add":
-		addCmd := flag.NewFlagSet("add", flag.ContinueOnError)
-		desc := addCmd.String("description", "", "Expense description")
-		amount := addCmd.Float64("amount", 0, "Expense amount")
-		if err := addCmd.Parse(args[1:]); err != nil {
-			return
+	idx := -1
+	for i, e := range et.expenses {
+		if e.ID == id {
+			idx = i
+			break
 		}
-		if *desc == "" || *amount <= 0 {
-			fmt.Println("Usage: add --description <text> --amount <number>")
-			return
+	}
+	if idx == -1 {
+		return ErrNotFound
+	}
+
+	updated := et.expenses[idx]
+	if patch.Description != nil {
+		updated.Description = *patch.Description
+	}
+	if patch.Category != nil {
+		updated.Category = *patch.Category
+	}
+	if patch.Amount != nil {
+		updated.Amount = *patch.Amount
+	}
+	if patch.Date != nil {
+		updated.Date = *patch.Date
+	}
+	et.expenses[idx] = updated
+	return et.repo.Save(et.expenses)
+}
+
+// Import adds expenses to the tracker. In merge mode (replace=false),
+// expenses whose ID collides with an existing one are re-assigned a fresh
+// ID; in replace mode the existing expenses are discarded and imported
+// expenses are renumbered from 1. It returns the number of expenses
+// accepted. Replacing a non-empty tracker with an empty import set is
+// refused (ErrEmptyReplace) since it would silently discard all existing
+// expenses.
+func (et *ExpenseTracker) Import(expenses []Expense, replace bool) (int, error) {
+	if replace {
+		if len(expenses) == 0 && len(et.expenses) > 0 {
+			return 0, ErrEmptyReplace
 		}
-		id := tracker.Add(*desc, *amount)
-		fmt.Printf("Expense added successfully (ID: %d)\n", id)
-
-	case "list":
-		listCmd := flag.NewFlagSet("list", flag.ContinueOnError)
-		_ = listCmd.Parse(args[1:])
-		tracker.List()
-
-	case "summary":
-		sumCmd := flag.NewFlagSet("summary", flag.ContinueOnError)
-		month := sumCmd.Int("month", 0, "Month number (1-12)")
-		if err := sumCmd.Parse(args[1:]); err != nil {
-			return
+		for i := range expenses {
+			expenses[i].ID = i + 1
 		}
-		if *month < 0 || *month > 12 {
-			fmt.Println("Usage: summary [--month 1..12]")
-			return
+		if err := et.repo.Save(expenses); err != nil {
+			return 0, err
 		}
-		tracker.Summary(*month)
+		et.expenses = expenses
+		et.nextID = len(expenses) + 1
+		return len(expenses), nil
+	}
 
-	case "delete":
-		delCmd := flag.NewFlagSet("delete", flag.ContinueOnError)
-		id := delCmd.Int("id", 0, "Expense ID")
-		if err := delCmd.Parse(args[1:]); err != nil {
-			return
-		}
-		if *id <= 0 {
-			fmt.Println("Usage: delete --id <number>")
-			return
+	existing := map[int]bool{}
+	for _, e := range et.expenses {
+		existing[e.ID] = true
+	}
+	for i := range expenses {
+		if existing[expenses[i].ID] {
+			expenses[i].ID = et.nextID
 		}
-		if tracker.Delete(*id) {
-			fmt.Println("Expense deleted successfully")
-		} else {
-			fmt.Println("Expense not found")
+		existing[expenses[i].ID] = true
+		if expenses[i].ID >= et.nextID {
+			et.nextID = expenses[i].ID + 1
 		}
-
-	case "help":
-		printUsage()
-	default:
-		fmt.Println("Unknown command:", args[0])
-		printUsage()
 	}
+	merged := append(append([]Expense(nil), et.expenses...), expenses...)
+	if err := et.repo.Save(merged); err != nil {
+		return 0, err
+	}
+	et.expenses = merged
+	return len(expenses), nil
 }
 
-func printUsage() {
-	fmt.Println("Usage: expense-tracker <command> [--flags]")
-	fmt.Println("Commands:")
-	fmt.Println("  add --description <text> --amount <number>")
-	fmt.Println("  list")
-	fmt.Println("  summary [--month 1..12]")
-	fmt.Println("  delete --id <number>")
-	fmt.Println("Tip: run without args to enter interactive mode.")
-}
+func main() {
+	args := os.Args[1:]
+	storage, args := extractStorageFlag(args)
+	repo, err := openRepository(storage)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening storage:", err)
+		os.Exit(1)
+	}
+	tracker, err := NewExpenseTracker(repo)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading expenses:", err)
+		os.Exit(1)
+	}
 
-func repl(tracker *ExpenseTracker) {
-	sc := bufio.NewScanner(os.Stdin)
-	for {
-		fmt.Print("> ")
-		if !sc.Scan() {
-			break
-		}
-		line := strings.TrimSpace(sc.Text())
-		if line == "" {
-			continue
-		}
-		if line == "exit" || line == "quit" {
-			break
-		}
-		if line == "help" {
-			printUsage()
-			continue
-		}
-		argv := splitArgs(line)
-		runCommand(tracker, argv)
+	if len(args) == 0 {
+		fmt.Printf("Expense Tracker (%s storage). Type 'help' for commands, 'exit' to quit.\n", storage)
+		repl(tracker)
+		return
 	}
+	runCommand(tracker, args)
 }
 
 func splitArgs(s string) []string {
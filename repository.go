@@ -0,0 +1,66 @@
+package main
+
+import "time"
+
+// Repository persists expenses so they survive across process runs. CLI
+// commands operate on an ExpenseTracker, which loads its expenses and budgets
+// from a Repository implementation chosen at startup, writes every change
+// through to it, and delegates Query to it.
+type Repository interface {
+	Load() ([]Expense, error)
+	Save(expenses []Expense) error
+	Add(e Expense) error
+	Delete(id int) error
+	Query(filter ExpenseFilter) ([]Expense, error)
+
+	LoadBudgets() ([]Budget, error)
+	SaveBudgets(budgets []Budget) error
+}
+
+// Searcher is implemented by repositories that support full-text search over
+// expense descriptions. Currently only the SQLite backend (built with
+// "-tags fts5") implements it.
+type Searcher interface {
+	SearchDescriptions(query string) ([]Expense, error)
+}
+
+// ExpenseFilter narrows the results of Repository.Query. Zero-value fields
+// are treated as "no constraint".
+type ExpenseFilter struct {
+	Month    int
+	Category string
+	From     time.Time
+	To       time.Time
+}
+
+func (f ExpenseFilter) matches(e Expense) bool {
+	if f.Month != 0 && int(e.Date.Month()) != f.Month {
+		return false
+	}
+	if f.Category != "" && e.Category != f.Category {
+		return false
+	}
+	if !f.From.IsZero() && e.Date.Before(f.From) {
+		return false
+	}
+	// To is a calendar day (parsed at midnight), but e.Date carries a
+	// time-of-day, so compare against the start of the following day rather
+	// than the To instant itself — otherwise expenses from the To day itself
+	// are excluded.
+	if !f.To.IsZero() && !e.Date.Before(f.To.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
+
+// filterExpenses applies f to expenses in-memory; repository implementations
+// that can't push the filter down to storage use this as a fallback.
+func filterExpenses(expenses []Expense, f ExpenseFilter) []Expense {
+	var out []Expense
+	for _, e := range expenses {
+		if f.matches(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
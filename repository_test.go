@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		t.Fatalf("parsing date %q: %v", s, err)
+	}
+	return d
+}
+
+func TestExpenseFilterMatches(t *testing.T) {
+	e := Expense{
+		Date:        mustDate(t, "2026-03-15"),
+		Description: "coffee",
+		Category:    "Food",
+		Amount:      4.5,
+	}
+
+	tests := []struct {
+		name   string
+		filter ExpenseFilter
+		want   bool
+	}{
+		{"no constraints", ExpenseFilter{}, true},
+		{"matching month", ExpenseFilter{Month: 3}, true},
+		{"non-matching month", ExpenseFilter{Month: 4}, false},
+		{"matching category", ExpenseFilter{Category: "Food"}, true},
+		{"non-matching category", ExpenseFilter{Category: "Rent"}, false},
+		{"on from date", ExpenseFilter{From: mustDate(t, "2026-03-15")}, true},
+		{"before from date", ExpenseFilter{From: mustDate(t, "2026-03-16")}, false},
+		{"on to date", ExpenseFilter{To: mustDate(t, "2026-03-15")}, true},
+		{"after to date", ExpenseFilter{To: mustDate(t, "2026-03-14")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(e); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestTracker(t *testing.T) *ExpenseTracker {
+	t.Helper()
+	tracker, err := NewExpenseTracker(NewInMemoryRepository())
+	if err != nil {
+		t.Fatalf("NewExpenseTracker: %v", err)
+	}
+	return tracker
+}
+
+func TestExpenseTrackerUpdate(t *testing.T) {
+	tracker := newTestTracker(t)
+	id, err := tracker.Add("lunch", "Food", 10)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	newDesc := "dinner"
+	newAmount := 25.0
+	if err := tracker.Update(id, ExpensePatch{Description: &newDesc, Amount: &newAmount}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got := tracker.expenses[0]
+	if got.Description != newDesc || got.Amount != newAmount {
+		t.Errorf("got %+v, want description %q and amount %v", got, newDesc, newAmount)
+	}
+	if got.Category != "Food" {
+		t.Errorf("unset Category field was touched: got %q, want %q", got.Category, "Food")
+	}
+}
+
+func TestExpenseTrackerUpdateNotFound(t *testing.T) {
+	tracker := newTestTracker(t)
+	newDesc := "x"
+	err := tracker.Update(99, ExpensePatch{Description: &newDesc})
+	if err != ErrNotFound {
+		t.Fatalf("Update() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestExpenseTrackerUpdateInvalidAmount(t *testing.T) {
+	tracker := newTestTracker(t)
+	id, err := tracker.Add("lunch", "Food", 10)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	badAmount := -5.0
+	err = tracker.Update(id, ExpensePatch{Amount: &badAmount})
+	if err == nil {
+		t.Fatal("Update() with non-positive amount should fail")
+	}
+}
+
+func TestExpenseTrackerImportMerge(t *testing.T) {
+	tracker := newTestTracker(t)
+	existingID, err := tracker.Add("existing", "Food", 5)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	imported := []Expense{
+		{ID: existingID, Date: mustDate(t, "2026-01-01"), Description: "conflict", Amount: 7},
+		{ID: existingID + 100, Date: mustDate(t, "2026-01-02"), Description: "fresh", Amount: 8},
+	}
+	accepted, err := tracker.Import(imported, false)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if accepted != 2 {
+		t.Fatalf("accepted = %d, want 2", accepted)
+	}
+	if len(tracker.expenses) != 3 {
+		t.Fatalf("len(expenses) = %d, want 3", len(tracker.expenses))
+	}
+
+	ids := map[int]int{}
+	for _, e := range tracker.expenses {
+		ids[e.ID]++
+	}
+	for id, count := range ids {
+		if count != 1 {
+			t.Errorf("ID %d appears %d times, want a unique ID per expense", id, count)
+		}
+	}
+}
+
+func TestExpenseTrackerImportReplace(t *testing.T) {
+	tracker := newTestTracker(t)
+	if _, err := tracker.Add("existing", "Food", 5); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	imported := []Expense{
+		{ID: 999, Date: mustDate(t, "2026-01-01"), Description: "a", Amount: 1},
+		{ID: 999, Date: mustDate(t, "2026-01-02"), Description: "b", Amount: 2},
+	}
+	accepted, err := tracker.Import(imported, true)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if accepted != 2 {
+		t.Fatalf("accepted = %d, want 2", accepted)
+	}
+	if len(tracker.expenses) != 2 {
+		t.Fatalf("len(expenses) = %d, want 2 (replace should discard existing)", len(tracker.expenses))
+	}
+	if tracker.expenses[0].ID == tracker.expenses[1].ID {
+		t.Errorf("replace should renumber imported expenses with unique IDs, got %d and %d",
+			tracker.expenses[0].ID, tracker.expenses[1].ID)
+	}
+}
+
+func TestExpenseTrackerImportReplaceEmptyRefused(t *testing.T) {
+	tracker := newTestTracker(t)
+	if _, err := tracker.Add("existing", "Food", 5); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	_, err := tracker.Import(nil, true)
+	if !errors.Is(err, ErrEmptyReplace) {
+		t.Fatalf("Import(nil, true) error = %v, want ErrEmptyReplace", err)
+	}
+	if len(tracker.expenses) != 1 {
+		t.Fatalf("len(expenses) = %d, want 1 (existing expense should survive a refused replace)", len(tracker.expenses))
+	}
+}
+
+func TestNewExpenseTrackerDropsMalformedBudgets(t *testing.T) {
+	repo := NewInMemoryRepository()
+	if err := repo.SaveBudgets([]Budget{
+		{Month: 0, Category: "Food", Amount: 100},
+		{Month: 7, Category: "Food", Amount: 100},
+	}); err != nil {
+		t.Fatalf("SaveBudgets: %v", err)
+	}
+
+	tracker, err := NewExpenseTracker(repo)
+	if err != nil {
+		t.Fatalf("NewExpenseTracker: %v", err)
+	}
+	budgets := tracker.Budgets()
+	if len(budgets) != 1 {
+		t.Fatalf("len(budgets) = %d, want 1 (malformed Month should be dropped)", len(budgets))
+	}
+	if budgets[0].Month != 7 {
+		t.Errorf("budgets[0].Month = %d, want 7", budgets[0].Month)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chzyer/readline"
+)
+
+// DefaultHistoryPath returns "~/.expense-tracker/history".
+func DefaultHistoryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".expense-tracker", "history"), nil
+}
+
+// monthNames backs tab-completion for flags that take a month, e.g.
+// "summary --month <TAB>".
+var monthNames = []string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+
+// shellCompleter builds the PrefixCompleter tree driving tab-completion in
+// the interactive shell from the command registry: command names, then each
+// command's declared Completions, so "summary --<TAB>" suggests its flags
+// and "summary --month <TAB>" cycles the values a Completion lists.
+func shellCompleter() *readline.PrefixCompleter {
+	items := []readline.PrefixCompleterInterface{
+		readline.PcItem("help"),
+		readline.PcItem("exit"),
+	}
+	for _, name := range sortedCommandNames() {
+		items = append(items, readline.PcItem(name, flagCompleters(commands[name])...))
+	}
+	return readline.NewPrefixCompleter(items...)
+}
+
+func flagCompleters(cmd Command) []readline.PrefixCompleterInterface {
+	valuesByFlag := make(map[string][]string, len(cmd.Completions))
+	for _, c := range cmd.Completions {
+		valuesByFlag[c.Flag] = c.Values
+	}
+
+	items := make([]readline.PrefixCompleterInterface, 0, len(cmd.Flags))
+	for _, f := range cmd.Flags {
+		flagName := "--" + f.Name
+		var valueItems []readline.PrefixCompleterInterface
+		for _, v := range valuesByFlag[flagName] {
+			valueItems = append(valueItems, readline.PcItem(v))
+		}
+		items = append(items, readline.PcItem(flagName, valueItems...))
+	}
+	return items
+}
+
+// repl runs the interactive shell: a chzyer/readline instance with
+// persistent history, tab-completion, and graceful Ctrl-C/Ctrl-D handling.
+func repl(tracker *ExpenseTracker) {
+	historyPath, err := DefaultHistoryPath()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: could not resolve history file:", err)
+	} else if err := os.MkdirAll(filepath.Dir(historyPath), 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: could not create history directory:", err)
+		historyPath = ""
+	}
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          "> ",
+		HistoryFile:     historyPath,
+		AutoComplete:    shellCompleter(),
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error starting shell:", err)
+		return
+	}
+	defer rl.Close()
+
+	for {
+		line, err := rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			// Ctrl-C clears the current line rather than exiting, matching
+			// the convention used by sqlite3's shell.
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			break
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if line == "exit" || line == "quit" {
+			break
+		}
+		if line == "help" {
+			printUsage()
+			continue
+		}
+		runCommand(tracker, splitArgs(line))
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// storageEnvVar overrides the default storage backend when --storage isn't
+// passed on the command line.
+const storageEnvVar = "EXPENSE_TRACKER_STORAGE"
+
+// extractStorageFlag pulls a leading "--storage" / "--storage=X" global flag
+// out of args (it precedes the subcommand, e.g. "--storage csv add ..."),
+// falling back to EXPENSE_TRACKER_STORAGE and finally "json". It only
+// recognizes the flag in the global-flag region before the subcommand name,
+// so a subcommand flag value that happens to equal "--storage" (e.g.
+// `add --description "--storage"`) is left alone. It returns the resolved
+// backend name and the remaining args with the flag removed.
+func extractStorageFlag(args []string) (string, []string) {
+	storage := os.Getenv(storageEnvVar)
+	if storage == "" {
+		storage = "json"
+	}
+
+	i := 0
+	for i < len(args) {
+		arg := args[i]
+		switch {
+		case arg == "--storage" && i+1 < len(args):
+			storage = args[i+1]
+			i += 2
+		case strings.HasPrefix(arg, "--storage="):
+			storage = strings.TrimPrefix(arg, "--storage=")
+			i++
+		default:
+			// The first arg that isn't a --storage flag is the subcommand
+			// name; stop consuming global flags here.
+			return storage, args[i:]
+		}
+	}
+	return storage, args[i:]
+}
+
+// openRepository builds the Repository named by storage ("json", "csv",
+// "sqlite", or "memory").
+func openRepository(storage string) (Repository, error) {
+	switch storage {
+	case "json":
+		path, err := DefaultJSONPath()
+		if err != nil {
+			return nil, err
+		}
+		return NewJSONRepository(path), nil
+	case "csv":
+		path, err := DefaultCSVPath()
+		if err != nil {
+			return nil, err
+		}
+		return NewCSVRepository(path), nil
+	case "sqlite":
+		return openSQLiteRepository()
+	case "memory":
+		return NewInMemoryRepository(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q (want json, csv, sqlite, or memory)", storage)
+	}
+}
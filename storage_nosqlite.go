@@ -0,0 +1,12 @@
+//go:build !fts5
+
+package main
+
+import "fmt"
+
+// openSQLiteRepository is stubbed out unless the binary is built with
+// "-tags fts5", since the SQLite backend needs cgo and the fts5-enabled
+// driver.
+func openSQLiteRepository() (Repository, error) {
+	return nil, fmt.Errorf("sqlite storage requires building with -tags fts5")
+}
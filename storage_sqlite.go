@@ -0,0 +1,15 @@
+//go:build fts5
+
+package main
+
+func openSQLiteRepository() (Repository, error) {
+	dbPath, err := DefaultSQLitePath()
+	if err != nil {
+		return nil, err
+	}
+	jsonPath, err := DefaultJSONPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewSQLiteRepository(dbPath, jsonPath)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// csvHeader is the column order used by CSVRepository and the export/import
+// commands that share its encoding.
+var csvHeader = []string{"id", "date", "description", "category", "amount"}
+
+// budgetCSVHeader is the column order for the sibling budgets.csv file.
+var budgetCSVHeader = []string{"month", "category", "amount"}
+
+// CSVRepository persists expenses as a CSV file on disk.
+type CSVRepository struct {
+	path string
+}
+
+// NewCSVRepository returns a repository backed by the CSV file at path.
+func NewCSVRepository(path string) *CSVRepository {
+	return &CSVRepository{path: path}
+}
+
+// DefaultCSVPath returns "~/.expense-tracker/expenses.csv".
+func DefaultCSVPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".expense-tracker", "expenses.csv"), nil
+}
+
+func (r *CSVRepository) Load() ([]Expense, error) {
+	f, err := os.Open(r.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	var expenses []Expense
+	for _, row := range rows[1:] { // skip header
+		e, err := expenseFromCSVRow(row)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, e)
+	}
+	return expenses, nil
+}
+
+func (r *CSVRepository) Save(expenses []Expense) error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(r.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, e := range expenses {
+		if err := w.Write(expenseToCSVRow(e)); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func (r *CSVRepository) Add(e Expense) error {
+	expenses, err := r.Load()
+	if err != nil {
+		return err
+	}
+	expenses = append(expenses, e)
+	return r.Save(expenses)
+}
+
+func (r *CSVRepository) Delete(id int) error {
+	expenses, err := r.Load()
+	if err != nil {
+		return err
+	}
+	for i, e := range expenses {
+		if e.ID == id {
+			expenses = append(expenses[:i], expenses[i+1:]...)
+			return r.Save(expenses)
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *CSVRepository) Query(filter ExpenseFilter) ([]Expense, error) {
+	expenses, err := r.Load()
+	if err != nil {
+		return nil, err
+	}
+	return filterExpenses(expenses, filter), nil
+}
+
+func expenseToCSVRow(e Expense) []string {
+	return []string{
+		strconv.Itoa(e.ID),
+		e.Date.Format("2006-01-02"),
+		e.Description,
+		e.Category,
+		strconv.FormatFloat(e.Amount, 'f', 2, 64),
+	}
+}
+
+func expenseFromCSVRow(row []string) (Expense, error) {
+	if len(row) < 5 {
+		return Expense{}, fmt.Errorf("malformed CSV row: %v", row)
+	}
+	id, err := strconv.Atoi(row[0])
+	if err != nil {
+		return Expense{}, err
+	}
+	date, err := time.Parse("2006-01-02", row[1])
+	if err != nil {
+		return Expense{}, err
+	}
+	amount, err := strconv.ParseFloat(row[4], 64)
+	if err != nil {
+		return Expense{}, err
+	}
+	return Expense{ID: id, Date: date, Description: row[2], Category: row[3], Amount: amount}, nil
+}
+
+// budgetsPath stores budgets alongside the expenses file, e.g.
+// "~/.expense-tracker/budgets.csv" next to "expenses.csv".
+func (r *CSVRepository) budgetsPath() string {
+	return filepath.Join(filepath.Dir(r.path), "budgets.csv")
+}
+
+func (r *CSVRepository) LoadBudgets() ([]Budget, error) {
+	f, err := os.Open(r.budgetsPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	var budgets []Budget
+	for _, row := range rows[1:] {
+		if len(row) < 3 {
+			return nil, fmt.Errorf("malformed budget CSV row: %v", row)
+		}
+		month, err := strconv.Atoi(row[0])
+		if err != nil {
+			return nil, err
+		}
+		amount, err := strconv.ParseFloat(row[2], 64)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, Budget{Month: month, Category: row[1], Amount: amount})
+	}
+	return budgets, nil
+}
+
+func (r *CSVRepository) SaveBudgets(budgets []Budget) error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(r.budgetsPath())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(budgetCSVHeader); err != nil {
+		return err
+	}
+	for _, b := range budgets {
+		row := []string{
+			strconv.Itoa(b.Month),
+			b.Category,
+			strconv.FormatFloat(b.Amount, 'f', 2, 64),
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// JSONRepository persists expenses as a single JSON array on disk.
+type JSONRepository struct {
+	path string
+}
+
+// NewJSONRepository returns a repository backed by the JSON file at path.
+func NewJSONRepository(path string) *JSONRepository {
+	return &JSONRepository{path: path}
+}
+
+// DefaultJSONPath returns "~/.expense-tracker/expenses.json".
+func DefaultJSONPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".expense-tracker", "expenses.json"), nil
+}
+
+func (r *JSONRepository) Load() ([]Expense, error) {
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var expenses []Expense
+	if err := json.Unmarshal(data, &expenses); err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+func (r *JSONRepository) Save(expenses []Expense) error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(expenses, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data, 0o644)
+}
+
+func (r *JSONRepository) Add(e Expense) error {
+	expenses, err := r.Load()
+	if err != nil {
+		return err
+	}
+	expenses = append(expenses, e)
+	return r.Save(expenses)
+}
+
+func (r *JSONRepository) Delete(id int) error {
+	expenses, err := r.Load()
+	if err != nil {
+		return err
+	}
+	for i, e := range expenses {
+		if e.ID == id {
+			expenses = append(expenses[:i], expenses[i+1:]...)
+			return r.Save(expenses)
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *JSONRepository) Query(filter ExpenseFilter) ([]Expense, error) {
+	expenses, err := r.Load()
+	if err != nil {
+		return nil, err
+	}
+	return filterExpenses(expenses, filter), nil
+}
+
+// budgetsPath stores budgets alongside the expenses file, e.g.
+// "~/.expense-tracker/budgets.json" next to "expenses.json".
+func (r *JSONRepository) budgetsPath() string {
+	return filepath.Join(filepath.Dir(r.path), "budgets.json")
+}
+
+func (r *JSONRepository) LoadBudgets() ([]Budget, error) {
+	data, err := os.ReadFile(r.budgetsPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var budgets []Budget
+	if err := json.Unmarshal(data, &budgets); err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+func (r *JSONRepository) SaveBudgets(budgets []Budget) error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(budgets, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.budgetsPath(), data, 0o644)
+}
@@ -0,0 +1,54 @@
+package main
+
+// InMemoryRepository keeps expenses in a process-local slice. It backs
+// "--storage memory" and is the Repository implementation used by tests.
+type InMemoryRepository struct {
+	expenses []Expense
+	budgets  []Budget
+}
+
+// NewInMemoryRepository returns an empty in-memory repository.
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{}
+}
+
+func (r *InMemoryRepository) Load() ([]Expense, error) {
+	out := make([]Expense, len(r.expenses))
+	copy(out, r.expenses)
+	return out, nil
+}
+
+func (r *InMemoryRepository) Save(expenses []Expense) error {
+	r.expenses = append([]Expense(nil), expenses...)
+	return nil
+}
+
+func (r *InMemoryRepository) Add(e Expense) error {
+	r.expenses = append(r.expenses, e)
+	return nil
+}
+
+func (r *InMemoryRepository) Delete(id int) error {
+	for i, e := range r.expenses {
+		if e.ID == id {
+			r.expenses = append(r.expenses[:i], r.expenses[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *InMemoryRepository) Query(filter ExpenseFilter) ([]Expense, error) {
+	return filterExpenses(r.expenses, filter), nil
+}
+
+func (r *InMemoryRepository) LoadBudgets() ([]Budget, error) {
+	out := make([]Budget, len(r.budgets))
+	copy(out, r.budgets)
+	return out, nil
+}
+
+func (r *InMemoryRepository) SaveBudgets(budgets []Budget) error {
+	r.budgets = append([]Budget(nil), budgets...)
+	return nil
+}
@@ -0,0 +1,248 @@
+//go:build fts5
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteRepository persists expenses in a SQLite database with an fts5
+// virtual table mirroring the description column, so descriptions can be
+// full-text searched. Built only when the "fts5" build tag is set, since it
+// requires cgo and the fts5-enabled sqlite3 driver.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// DefaultSQLitePath returns "~/.expense-tracker/expenses.db".
+func DefaultSQLitePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".expense-tracker", "expenses.db"), nil
+}
+
+// NewSQLiteRepository opens (creating if necessary) the SQLite database at
+// path. If the database is empty and a JSON store already exists at
+// jsonPath, its contents are imported as a one-time migration.
+func NewSQLiteRepository(path, jsonPath string) (*SQLiteRepository, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+	r := &SQLiteRepository{db: db}
+	if err := r.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := r.importFromJSONIfEmpty(jsonPath); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *SQLiteRepository) migrate() error {
+	_, err := r.db.Exec(`
+		CREATE TABLE IF NOT EXISTS expenses (
+			id          INTEGER PRIMARY KEY,
+			date        TEXT NOT NULL,
+			description TEXT NOT NULL,
+			category    TEXT NOT NULL DEFAULT '',
+			amount      REAL NOT NULL
+		);
+		CREATE VIRTUAL TABLE IF NOT EXISTS expenses_fts USING fts5(
+			description, content='expenses', content_rowid='id'
+		);
+		CREATE TRIGGER IF NOT EXISTS expenses_ai AFTER INSERT ON expenses BEGIN
+			INSERT INTO expenses_fts(rowid, description) VALUES (new.id, new.description);
+		END;
+		CREATE TRIGGER IF NOT EXISTS expenses_ad AFTER DELETE ON expenses BEGIN
+			INSERT INTO expenses_fts(expenses_fts, rowid, description) VALUES ('delete', old.id, old.description);
+		END;
+		CREATE TABLE IF NOT EXISTS budgets (
+			month    INTEGER NOT NULL,
+			category TEXT NOT NULL,
+			amount   REAL NOT NULL,
+			PRIMARY KEY (month, category)
+		);
+	`)
+	return err
+}
+
+// importFromJSONIfEmpty migrates a pre-existing JSON store into SQLite the
+// first time the database is used, so switching --storage doesn't lose data.
+func (r *SQLiteRepository) importFromJSONIfEmpty(jsonPath string) error {
+	if jsonPath == "" {
+		return nil
+	}
+	// user_version marks that the migration check has already run, so a
+	// database the user later empties is not refilled from the JSON file.
+	var migrated int
+	if err := r.db.QueryRow(`PRAGMA user_version`).Scan(&migrated); err != nil || migrated > 0 {
+		return err
+	}
+	var count int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM expenses`).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		expenses, err := NewJSONRepository(jsonPath).Load()
+		if err != nil {
+			return err
+		}
+		if err := r.Save(expenses); err != nil {
+			return err
+		}
+	}
+	_, err := r.db.Exec(`PRAGMA user_version = 1`)
+	return err
+}
+
+func (r *SQLiteRepository) Load() ([]Expense, error) {
+	rows, err := r.db.Query(`SELECT id, date, description, category, amount FROM expenses ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []Expense
+	for rows.Next() {
+		var e Expense
+		var date string
+		if err := rows.Scan(&e.ID, &date, &e.Description, &e.Category, &e.Amount); err != nil {
+			return nil, err
+		}
+		e.Date, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, e)
+	}
+	return expenses, rows.Err()
+}
+
+func (r *SQLiteRepository) Save(expenses []Expense) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM expenses`); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, e := range expenses {
+		if _, err := tx.Exec(`INSERT INTO expenses (id, date, description, category, amount) VALUES (?, ?, ?, ?, ?)`,
+			e.ID, e.Date.Format("2006-01-02"), e.Description, e.Category, e.Amount); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *SQLiteRepository) Add(e Expense) error {
+	_, err := r.db.Exec(`INSERT INTO expenses (id, date, description, category, amount) VALUES (?, ?, ?, ?, ?)`,
+		e.ID, e.Date.Format("2006-01-02"), e.Description, e.Category, e.Amount)
+	return err
+}
+
+func (r *SQLiteRepository) Delete(id int) error {
+	res, err := r.db.Exec(`DELETE FROM expenses WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteRepository) Query(filter ExpenseFilter) ([]Expense, error) {
+	expenses, err := r.Load()
+	if err != nil {
+		return nil, err
+	}
+	return filterExpenses(expenses, filter), nil
+}
+
+func (r *SQLiteRepository) LoadBudgets() ([]Budget, error) {
+	rows, err := r.db.Query(`SELECT month, category, amount FROM budgets ORDER BY month, category`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var budgets []Budget
+	for rows.Next() {
+		var b Budget
+		if err := rows.Scan(&b.Month, &b.Category, &b.Amount); err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, b)
+	}
+	return budgets, rows.Err()
+}
+
+func (r *SQLiteRepository) SaveBudgets(budgets []Budget) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM budgets`); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, b := range budgets {
+		if _, err := tx.Exec(`INSERT INTO budgets (month, category, amount) VALUES (?, ?, ?)`,
+			b.Month, b.Category, b.Amount); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// SearchDescriptions runs a full-text search over expense descriptions using
+// the expenses_fts virtual table.
+func (r *SQLiteRepository) SearchDescriptions(query string) ([]Expense, error) {
+	rows, err := r.db.Query(`
+		SELECT e.id, e.date, e.description, e.category, e.amount
+		FROM expenses_fts f JOIN expenses e ON e.id = f.rowid
+		WHERE expenses_fts MATCH ?
+		ORDER BY e.id
+	`, query)
+	if err != nil {
+		return nil, fmt.Errorf("searching descriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var expenses []Expense
+	for rows.Next() {
+		var e Expense
+		var date string
+		if err := rows.Scan(&e.ID, &date, &e.Description, &e.Category, &e.Amount); err != nil {
+			return nil, err
+		}
+		e.Date, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, e)
+	}
+	return expenses, rows.Err()
+}